@@ -0,0 +1,91 @@
+package napigo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+
+	"github.com/bodgit/sevenzip"
+)
+
+// sevenZipPassword is the fixed password Napiprojekt uses to protect its 7z
+// subtitle archives.
+const sevenZipPassword = "iBlm8NTigvru0Jr0"
+
+// ArchiveFormat identifies the detected compression format of a downloaded
+// subtitle payload.
+type ArchiveFormat int
+
+const (
+	// FormatNone means the payload isn't a recognized archive.
+	FormatNone ArchiveFormat = iota
+	// FormatGzip is a gzip-compressed payload.
+	FormatGzip
+	// FormatZip is a ZIP archive.
+	FormatZip
+	// Format7z is a 7z archive, as shipped by Napiprojekt (password
+	// protected with sevenZipPassword).
+	Format7z
+)
+
+var (
+	gzipMagic     = []byte{0x1f, 0x8b}
+	zipMagic      = []byte{0x50, 0x4b, 0x03, 0x04}
+	sevenZipMagic = []byte{0x37, 0x7a, 0xbc, 0xaf, 0x27, 0x1c}
+)
+
+// detectFormat inspects a payload's magic bytes and returns its ArchiveFormat.
+func detectFormat(data []byte) ArchiveFormat {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		return FormatGzip
+	case bytes.HasPrefix(data, zipMagic):
+		return FormatZip
+	case bytes.HasPrefix(data, sevenZipMagic):
+		return Format7z
+	default:
+		return FormatNone
+	}
+}
+
+// decodeArchive transparently decompresses data according to its detected
+// format, returning the first (or only) file's contents. Data that isn't a
+// recognized archive is returned unchanged.
+func decodeArchive(data []byte) ([]byte, error) {
+	switch detectFormat(data) {
+	case FormatGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case FormatZip:
+		contents, _, err := unzipSingleFile(data)
+		return contents, err
+	case Format7z:
+		return extract7z(data)
+	default:
+		return data, nil
+	}
+}
+
+// extract7z extracts the first file from a 7z archive protected with
+// Napiprojekt's fixed password.
+func extract7z(data []byte) ([]byte, error) {
+	r, err := sevenzip.NewReaderWithPassword(bytes.NewReader(data), int64(len(data)), sevenZipPassword)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.File) == 0 {
+		return nil, errors.New("7z archive is empty")
+	}
+	f := r.File[0]
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
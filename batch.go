@@ -0,0 +1,188 @@
+package napigo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Request is a single file/language search to run as part of a SearchBatch.
+type Request struct {
+	FileName  string
+	Languages []string
+}
+
+// BatchResult is the SearchBatch outcome for one Request: either a result
+// list or an error.
+type BatchResult struct {
+	Request Request
+	Results []SearchResult
+	Err     error
+}
+
+// Progress reports how many SearchBatch requests have completed.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// BatchOption configures SearchBatch.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	concurrency int
+	timeout     time.Duration
+	retries     int
+	onProgress  func(Progress)
+}
+
+// WithConcurrency sets how many requests SearchBatch processes at once.
+// The default is 4.
+func WithConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithTimeout sets a per-request timeout. The default is 30s; 0 disables
+// the timeout.
+func WithTimeout(d time.Duration) BatchOption {
+	return func(c *batchConfig) {
+		c.timeout = d
+	}
+}
+
+// WithRetries sets how many times a request is retried, with exponential
+// backoff, after an error. The default is 2.
+func WithRetries(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n >= 0 {
+			c.retries = n
+		}
+	}
+}
+
+// WithProgress registers a callback invoked after each request completes.
+func WithProgress(f func(Progress)) BatchOption {
+	return func(c *batchConfig) {
+		c.onProgress = f
+	}
+}
+
+// SearchBatch fans requests out over a bounded worker pool, querying
+// provider for each one, and returns a channel streaming one BatchResult per
+// Request as it completes. provider is typically a MultiProvider so that
+// fallback providers (e.g. OpenSubtitles, napisy24) are consulted during
+// search, not just Napiprojekt. The channel is closed once every request has
+// been processed or ctx is done.
+func SearchBatch(ctx context.Context, provider SubtitleProvider, requests []Request, opts ...BatchOption) <-chan BatchResult {
+	cfg := &batchConfig{
+		concurrency: 4,
+		timeout:     30 * time.Second,
+		retries:     2,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	jobs := make(chan Request)
+	out := make(chan BatchResult)
+	total := len(requests)
+	var done int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				res := searchWithRetry(ctx, provider, req, cfg)
+				if cfg.onProgress != nil {
+					cfg.onProgress(Progress{Done: int(atomic.AddInt32(&done, 1)), Total: total})
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, req := range requests {
+			select {
+			case jobs <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+func searchWithRetry(ctx context.Context, provider SubtitleProvider, req Request, cfg *batchConfig) BatchResult {
+	var lastErr error
+	for attempt := 0; attempt <= cfg.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff(attempt)):
+			case <-ctx.Done():
+				return BatchResult{Request: req, Err: ctx.Err()}
+			}
+		}
+
+		results, err := searchWithTimeout(ctx, provider, req, cfg.timeout)
+		if err == nil {
+			return BatchResult{Request: req, Results: results}
+		}
+		lastErr = err
+	}
+	return BatchResult{Request: req, Err: lastErr}
+}
+
+// searchWithTimeout runs provider.Search, which has no context of its own,
+// in a goroutine and bounds how long the caller waits for it via ctx/timeout.
+func searchWithTimeout(ctx context.Context, provider SubtitleProvider, req Request, timeout time.Duration) ([]SearchResult, error) {
+	type searchResult struct {
+		results []SearchResult
+		err     error
+	}
+	done := make(chan searchResult, 1)
+	go func() {
+		results, err := provider.Search(req.FileName, req.Languages)
+		done <- searchResult{results, err}
+	}()
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case r := <-done:
+		return r.results, r.err
+	case <-timeoutCh:
+		return nil, fmt.Errorf("napigo: search for %q timed out after %s", req.FileName, timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// backoff returns the exponential backoff delay before retry attempt, which
+// is 1-indexed (attempt 1 is the first retry).
+func backoff(attempt int) time.Duration {
+	return time.Duration(1<<uint(attempt-1)) * 500 * time.Millisecond
+}
@@ -1,16 +1,30 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/quhar/napigo"
+	"github.com/quhar/napigo/subs"
 )
 
 var (
-	lang = flag.String("language", "ENG", "Language in which subtitles should be downloaded, if subtitles in provided language are not found, Polish is used")
+	lang       = flag.String("language", "ENG", "Comma separated list of languages to search for, e.g. pl,en,de. The first one found is downloaded, Polish is used as a last resort")
+	osUA       = flag.String("opensubtitles-ua", "", "OpenSubtitles API user agent; when set, OpenSubtitles is used as a fallback when Napiprojekt has no match")
+	napisy24UA = flag.String("napisy24-user", "", "napisy24.pl account user; when set (together with -napisy24-pass), napisy24 is used as a fallback when Napiprojekt has no match")
+	napisy24AP = flag.String("napisy24-pass", "", "napisy24.pl account password")
+	format     = flag.String("format", "raw", "Subtitle output format: srt, vtt or raw")
+	fps        = flag.Float64("fps", subs.DefaultFPS, "Frame rate used to convert MicroDVD subtitles to time based formats")
+	workers    = flag.Int("j", 4, "Number of files to search/download concurrently")
+	timeout    = flag.Duration("timeout", 60*time.Second, "Per-file search timeout")
+	retries    = flag.Int("retries", 2, "Number of times a failed search is retried, with exponential backoff")
 )
 
 func main() {
@@ -20,26 +34,113 @@ func main() {
 		flag.Usage()
 		os.Exit(1)
 	}
-	n := napigo.New()
-	for _, fname := range flag.Args() {
-		fmt.Printf("Downloading subtitles for %q...\n", fname)
-		if err := download(n, fname); err != nil {
-			fmt.Println(err)
+	if *format != "raw" && *format != "srt" && *format != "vtt" {
+		fmt.Printf("invalid -format %q, must be one of raw, srt, vtt\n", *format)
+		os.Exit(1)
+	}
+	if *workers < 1 {
+		fmt.Printf("invalid -j %d, must be >= 1\n", *workers)
+		os.Exit(1)
+	}
+
+	napi := napigo.New()
+	providers := []napigo.SubtitleProvider{napi}
+	if *osUA != "" {
+		providers = append(providers, napigo.NewOpenSubtitles(*osUA))
+	}
+	if *napisy24UA != "" {
+		providers = append(providers, napigo.NewNapisy24(*napisy24UA, *napisy24AP))
+	}
+	var provider napigo.SubtitleProvider = providers[0]
+	if len(providers) > 1 {
+		provider = napigo.NewMultiProvider(providers...)
+	}
+
+	langs := strings.Split(*lang, ",")
+	for i := range langs {
+		langs[i] = strings.TrimSpace(langs[i])
+	}
+
+	fnames := flag.Args()
+	requests := make([]napigo.Request, len(fnames))
+	for i, fname := range fnames {
+		requests[i] = napigo.Request{FileName: fname, Languages: langs}
+	}
+
+	results := napigo.SearchBatch(context.Background(), provider, requests,
+		napigo.WithConcurrency(*workers),
+		napigo.WithTimeout(*timeout),
+		napigo.WithRetries(*retries),
+		napigo.WithProgress(func(p napigo.Progress) {
+			fmt.Printf("Searched [%d/%d]\n", p.Done, p.Total)
+		}),
+	)
+
+	sem := make(chan struct{}, *workers)
+	var wg sync.WaitGroup
+	for res := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res napigo.BatchResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if res.Err != nil {
+				fmt.Printf("failed to search subtitles for %q: %v\n", res.Request.FileName, res.Err)
+				return
+			}
+			if err := download(provider, res.Request.FileName, pickLanguage(res.Results, res.Request.Languages)); err != nil {
+				fmt.Println(err)
+			}
+		}(res)
+	}
+	wg.Wait()
+}
+
+// pickLanguage returns the first found language, falling back to the first
+// requested one (Napiprojekt returns Polish subtitles for an unmatched
+// language anyway).
+func pickLanguage(results []napigo.SearchResult, requested []string) string {
+	for _, r := range results {
+		if r.Found {
+			return r.Lang
 		}
 	}
+	return requested[0]
 }
 
-func download(n *napigo.Napi, fname string) error {
-	s, err := n.Download(fname, *lang)
+func download(n napigo.SubtitleProvider, fname, lang string) error {
+	s, err := n.Download(fname, lang)
 	if err != nil {
 		return fmt.Errorf("failed to download subtitles for %q: %v", fname, err)
 	}
-	subFname, err := napigo.SubFileName(fname)
+
+	ext := "txt"
+	out := []byte(s)
+	if *format == "srt" || *format == "vtt" {
+		cues, err := subs.Parse([]byte(s), *fps)
+		if err != nil {
+			return fmt.Errorf("failed to parse subtitles for %q: %v", fname, err)
+		}
+		var buf bytes.Buffer
+		ext = *format
+		if *format == "srt" {
+			err = subs.WriteSRT(&buf, cues)
+		} else {
+			err = subs.WriteVTT(&buf, cues)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert subtitles for %q: %v", fname, err)
+		}
+		out = buf.Bytes()
+	}
+
+	subFname, err := napigo.SubFileName(fname, ext)
 	if err != nil {
 		return fmt.Errorf("failed to generate subtitles file name from %q: %v", fname, err)
 	}
 	fmt.Printf("Saving subtitles to: %s\n", subFname)
-	if err := ioutil.WriteFile(subFname, []byte(s), 0666); err != nil {
+	if err := ioutil.WriteFile(subFname, out, 0666); err != nil {
 		return fmt.Errorf("Failed to write subtitles to file %q: %v", fname, err)
 	}
 	return nil
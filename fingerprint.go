@@ -0,0 +1,69 @@
+package napigo
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileFingerprint holds everything subtitle providers need to identify a
+// video file. Napiprojekt and napisy24 both key off the MD5 hash, napisy24
+// additionally needs the file size and name. OpenSubtitles needs its own
+// 64-bit hash, which costs an extra seek-to-end read, so it's only computed
+// lazily via OpenSubtitlesHash for the one provider that needs it.
+type FileFingerprint struct {
+	MD5  []byte
+	Size int64
+	Name string
+
+	fname  string
+	osOnce sync.Once
+	osHash uint64
+	osErr  error
+}
+
+// Fingerprint computes the FileFingerprint for fname.
+func Fingerprint(fname string) (*FileFingerprint, error) {
+	h, size, err := hashFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	return &FileFingerprint{
+		MD5:   h,
+		Size:  size,
+		Name:  filepath.Base(fname),
+		fname: fname,
+	}, nil
+}
+
+// OpenSubtitlesHash lazily computes and caches fp's OpenSubtitles 64-bit
+// hash, so providers that don't need it (Napi, Napisy24) never pay for it.
+func (fp *FileFingerprint) OpenSubtitlesHash() (uint64, error) {
+	fp.osOnce.Do(func() {
+		fp.osHash, _, fp.osErr = OpenSubtitlesHash(fp.fname)
+	})
+	return fp.osHash, fp.osErr
+}
+
+// hashFile computes fname's MD5 over the first hashReadSize bytes and
+// returns the file's total size, hashing via HashReader so both come out of
+// a single read pass.
+func hashFile(fname string) ([]byte, int64, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	h, _, err := HashReader(io.LimitReader(f, hashReadSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return h, fi.Size(), nil
+}
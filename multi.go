@@ -0,0 +1,55 @@
+package napigo
+
+// MultiProvider queries a list of SubtitleProviders in order and returns the
+// first successful match. It is used to fall back from one provider to
+// another, e.g. from Napiprojekt to OpenSubtitles when a Polish-only result
+// comes back as NPc0.
+type MultiProvider struct {
+	Providers []SubtitleProvider
+}
+
+// NewMultiProvider returns a new MultiProvider querying the given providers
+// in order.
+func NewMultiProvider(providers ...SubtitleProvider) *MultiProvider {
+	return &MultiProvider{Providers: providers}
+}
+
+// Search queries each provider in order and returns the first result set
+// containing a match.
+func (m *MultiProvider) Search(fname string, langs []string) ([]SearchResult, error) {
+	var lastErr error
+	for _, p := range m.Providers {
+		results, err := p.Search(fname, langs)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, r := range results {
+			if r.Found {
+				return results, nil
+			}
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrSubsNotFound
+}
+
+// Download queries each provider in order and returns the first successful
+// download.
+func (m *MultiProvider) Download(fname, lang string) (string, error) {
+	var lastErr error
+	for _, p := range m.Providers {
+		s, err := p.Download(fname, lang)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return s, nil
+	}
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", ErrSubsNotFound
+}
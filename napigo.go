@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -49,8 +50,8 @@ const (
 
 // SearchResult is the the result returned by Search function.
 type SearchResult struct {
-	Lang      string
-	Subtitles string
+	Lang  string
+	Found bool
 }
 
 // Napi searches and downloads subtitles from Napiprojekt.pl.
@@ -85,14 +86,30 @@ func Hash(fname string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
+
 	buf := make([]byte, hashReadSize)
-	if _, err := f.Read(buf); err != nil {
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
 		return nil, err
 	}
-	h := md5.Sum(buf)
+	h := md5.Sum(buf[:n])
 	return h[:], nil
 }
 
+// HashReader streams r into an MD5 hasher, returning the hash and the number
+// of bytes read. It lets callers that need both a file's MD5 and its size
+// (like the napisy24 or OpenSubtitles providers) hash and measure a file in
+// a single pass.
+func HashReader(r io.Reader) ([]byte, int64, error) {
+	h := md5.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return nil, n, err
+	}
+	return h.Sum(nil), n, nil
+}
+
 // NapiHash returns value computed from video file hash. This value is used to search for subtitles.
 func NapiHash(h []byte) string {
 	if len(h) != md5.Size {
@@ -153,13 +170,15 @@ func prepRet(r []byte) string {
 }
 
 // Search returns list of subtitles found for provided video file and languages.
-func (n *Napi) Search(fname string, langs []string, download bool) ([]SearchResult, error) {
-	h, err := Hash(fname)
+// It implements SubtitleProvider; it only checks for a match, call Download
+// to fetch the actual subtitles.
+func (n *Napi) Search(fname string, langs []string) ([]SearchResult, error) {
+	fp, err := Fingerprint(fname)
 	if err != nil {
 		return nil, err
 	}
-	t := NapiHash(h)
-	strHash := fmt.Sprintf("%x", h)
+	t := NapiHash(fp.MD5)
+	strHash := fmt.Sprintf("%x", fp.MD5)
 	values := url.Values{}
 	values.Add("f", strHash)
 	values.Add("t", t)
@@ -175,21 +194,10 @@ func (n *Napi) Search(fname string, langs []string, download bool) ([]SearchResu
 		if err != nil {
 			return nil, err
 		}
-		r := SearchResult{
-			Lang: l,
-		}
-		if string(data) == "NPc0" {
-			results = append(results, r)
-			continue
-		}
-		if download {
-			subs, err := n.download(strHash, l)
-			if err != nil {
-				return nil, err
-			}
-			r.Subtitles = subs
-			results = append(results, r)
-		}
+		results = append(results, SearchResult{
+			Lang:  l,
+			Found: string(data) != "NPc0",
+		})
 	}
 
 	return results, nil
@@ -199,11 +207,28 @@ func (n *Napi) Search(fname string, langs []string, download bool) ([]SearchResu
 // If subtitles in provided language don't exists on the Napiprojekt server, Polish subtitles are returned.
 // (it's a Napiprojekt behavior).
 func (n *Napi) Download(fname, lang string) (string, error) {
-	h, err := Hash(fname)
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return "", err
+	}
+	content, _, err := n.download(fmt.Sprintf("%x", fp.MD5), lang)
 	if err != nil {
 		return "", err
 	}
-	return n.download(fmt.Sprintf("%x", h), lang)
+	return string(content), nil
+}
+
+// DownloadRaw returns subtitles for provided video file and language along
+// with the detected ArchiveFormat of the payload Napiprojekt sent, without
+// converting them to a string. Use this to persist the subtitles as-is with
+// their original extension (e.g. .srt/.txt/.mpl) instead of always getting a
+// decoded string.
+func (n *Napi) DownloadRaw(fname, lang string) ([]byte, ArchiveFormat, error) {
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return nil, FormatNone, err
+	}
+	return n.download(fmt.Sprintf("%x", fp.MD5), lang)
 }
 
 func (n *Napi) doQuery(url string) ([]byte, error) {
@@ -215,7 +240,11 @@ func (n *Napi) doQuery(url string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
-func (n *Napi) download(hash, lang string) (string, error) {
+// download fetches the subtitles identified by hash/lang from Napiprojekt and
+// transparently decompresses them. Napiprojekt historically ships subtitles
+// inside a password protected 7z archive, but other formats (gzip, zip) are
+// also handled, detected from the payload's magic bytes.
+func (n *Napi) download(hash, lang string) ([]byte, ArchiveFormat, error) {
 	v := url.Values{}
 	v.Set("downloaded_subtitles_lang", lang)
 	v.Set("downloaded_subtitles_txt", "1")
@@ -225,22 +254,27 @@ func (n *Napi) download(hash, lang string) (string, error) {
 	v.Set("mode", "1")
 	resp, err := n.client.PostForm(downloadURL, v)
 	if err != nil {
-		return "", err
+		return nil, FormatNone, err
 	}
 	defer resp.Body.Close()
 	d := xml.NewDecoder(resp.Body)
 	r := &result{}
 	if err := d.Decode(r); err != nil {
-		return "", err
+		return nil, FormatNone, err
 	}
 	if r.Status != "success" {
-		return "", ErrSubsNotFound
+		return nil, FormatNone, ErrSubsNotFound
 	}
 	data, err := base64.StdEncoding.DecodeString(r.Subtitles.Contents)
 	if err != nil {
-		return "", err
+		return nil, FormatNone, err
+	}
+	format := detectFormat(data)
+	content, err := decodeArchive(data)
+	if err != nil {
+		return nil, format, err
 	}
-	return string(data), nil
+	return content, format, nil
 }
 
 type result struct {
@@ -253,12 +287,13 @@ type subtitles struct {
 	Contents string `xml:"content"`
 }
 
-// SubFileName is a helper function which returns name for the subtitles file.
-func SubFileName(fname string) (string, error) {
+// SubFileName is a helper function which returns name for the subtitles
+// file, replacing fname's extension with ext (e.g. "srt", "vtt", "txt").
+func SubFileName(fname, ext string) (string, error) {
 	els := strings.Split(fname, ".")
 	l := len(els)
 	if l == 1 {
 		return "", fmt.Errorf("incorrect file name %q, no extension", fname)
 	}
-	return fmt.Sprintf("%s.txt", strings.Join(els[:l-1], ".")), nil
+	return fmt.Sprintf("%s.%s", strings.Join(els[:l-1], "."), ext), nil
 }
@@ -0,0 +1,44 @@
+package napigo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestHashShortFile pins the fix for a file smaller than hashReadSize: Hash
+// must hash exactly what's on disk instead of a short, zero-padded read.
+func TestHashShortFile(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "short.bin")
+	content := []byte("this file is much smaller than the 10MiB hash window")
+	if err := os.WriteFile(fname, content, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := Hash(fname)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	want := md5.Sum(content)
+	if !bytes.Equal(got, want[:]) {
+		t.Errorf("Hash() = %x, want %x", got, want)
+	}
+}
+
+func TestHashReader(t *testing.T) {
+	content := []byte("streamed content used to compute both md5 and size")
+	got, n, err := HashReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("HashReader: %v", err)
+	}
+	if n != int64(len(content)) {
+		t.Errorf("n = %d, want %d", n, len(content))
+	}
+	want := md5.Sum(content)
+	if !bytes.Equal(got, want[:]) {
+		t.Errorf("HashReader() = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package napigo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// napisy24URL is the napisy24.pl subtitle agent endpoint.
+var napisy24URL = "http://napisy24.pl/run/CheckSubAgent.php"
+
+// Napisy24 searches and downloads subtitles from napisy24.pl.
+type Napisy24 struct {
+	client   *http.Client
+	user     string
+	password string
+}
+
+// NewNapisy24 returns new Napisy24 provider using the given napisy24.pl
+// account credentials (ua/ap in their API).
+func NewNapisy24(user, password string) *Napisy24 {
+	return &Napisy24{
+		client:   &http.Client{Timeout: 60 * time.Second},
+		user:     user,
+		password: password,
+	}
+}
+
+// Search returns list of subtitles found on napisy24.pl for provided video
+// file and languages. napisy24.pl does not filter by language server-side,
+// so every requested language gets the same result.
+func (n *Napisy24) Search(fname string, langs []string) ([]SearchResult, error) {
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return nil, err
+	}
+	data, err := n.checkSub(fp)
+	if err != nil {
+		return nil, err
+	}
+	found := detectFormat(data) == FormatZip
+	var results []SearchResult
+	for _, l := range langs {
+		results = append(results, SearchResult{Lang: l, Found: found})
+	}
+	return results, nil
+}
+
+// Download returns string encoded subtitles for provided video file.
+// napisy24.pl has no notion of language, lang is accepted only to satisfy
+// SubtitleProvider.
+func (n *Napisy24) Download(fname, lang string) (string, error) {
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return "", err
+	}
+	data, err := n.checkSub(fp)
+	if err != nil {
+		return "", err
+	}
+	if detectFormat(data) != FormatZip {
+		return "", ErrSubsNotFound
+	}
+	subs, _, err := unzipSingleFile(data)
+	if err != nil {
+		return "", err
+	}
+	return string(subs), nil
+}
+
+// checkSub calls napisy24's CheckSubAgent.php and returns the raw response
+// body: a ZIP payload when subtitles were found, or a short non-ZIP
+// status/error body otherwise.
+func (n *Napisy24) checkSub(fp *FileFingerprint) ([]byte, error) {
+	v := url.Values{}
+	v.Set("postAction", "CheckSub")
+	v.Set("ua", n.user)
+	v.Set("ap", n.password)
+	v.Set("fh", fmt.Sprintf("%x", fp.MD5))
+	v.Set("fs", fmt.Sprintf("%d", fp.Size))
+	v.Set("fn", fp.Name)
+
+	resp, err := n.client.PostForm(napisy24URL, v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,171 @@
+package napigo
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+const openSubtitlesChunkSize = 65536
+
+// openSubtitlesSearchURL is the OpenSubtitles REST search endpoint. JSON is
+// returned when the client sets Accept: application/json.
+var openSubtitlesSearchURL = "https://rest.opensubtitles.org/search"
+
+// OpenSubtitles searches and downloads subtitles from OpenSubtitles.org.
+// The OpenSubtitles REST API requires every client to identify itself with
+// a registered user agent string.
+type OpenSubtitles struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewOpenSubtitles returns new OpenSubtitles provider using the given
+// registered user agent.
+func NewOpenSubtitles(userAgent string) *OpenSubtitles {
+	return &OpenSubtitles{
+		client:    &http.Client{Timeout: 60 * time.Second},
+		userAgent: userAgent,
+	}
+}
+
+// OpenSubtitlesHash computes the OpenSubtitles 64-bit hash used to identify
+// video files: the file size plus the sum of the first and last 64KB of the
+// file, each read as little-endian uint64 words.
+func OpenSubtitlesHash(fname string) (uint64, int64, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	size := fi.Size()
+	if size < openSubtitlesChunkSize {
+		return 0, 0, fmt.Errorf("file %q is smaller than %d bytes", fname, openSubtitlesChunkSize)
+	}
+
+	hash := uint64(size)
+	buf := make([]byte, openSubtitlesChunkSize)
+
+	if _, err := io.ReadFull(f, buf); err != nil {
+		return 0, 0, err
+	}
+	hash += sumLittleEndianUint64(buf)
+
+	if _, err := f.Seek(-openSubtitlesChunkSize, io.SeekEnd); err != nil {
+		return 0, 0, err
+	}
+	if _, err := io.ReadFull(f, buf); err != nil {
+		return 0, 0, err
+	}
+	hash += sumLittleEndianUint64(buf)
+
+	return hash, size, nil
+}
+
+func sumLittleEndianUint64(buf []byte) uint64 {
+	var sum uint64
+	for i := 0; i < len(buf); i += 8 {
+		sum += binary.LittleEndian.Uint64(buf[i : i+8])
+	}
+	return sum
+}
+
+// osEntry is a single match as returned by the OpenSubtitles REST search API.
+type osEntry struct {
+	SubLanguageID   string `json:"SubLanguageID"`
+	SubDownloadLink string `json:"SubDownloadLink"`
+}
+
+// Search returns list of subtitles found on OpenSubtitles for provided video
+// file and languages. It implements SubtitleProvider.
+func (o *OpenSubtitles) Search(fname string, langs []string) ([]SearchResult, error) {
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := fp.OpenSubtitlesHash()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, l := range langs {
+		entries, err := o.search(hash, fp.Size, l)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, SearchResult{
+			Lang:  l,
+			Found: len(entries) > 0,
+		})
+	}
+	return results, nil
+}
+
+// Download returns string encoded subtitles for provided video file and
+// language.
+func (o *OpenSubtitles) Download(fname, lang string) (string, error) {
+	fp, err := Fingerprint(fname)
+	if err != nil {
+		return "", err
+	}
+	hash, err := fp.OpenSubtitlesHash()
+	if err != nil {
+		return "", err
+	}
+	entries, err := o.search(hash, fp.Size, lang)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "", ErrSubsNotFound
+	}
+
+	resp, err := o.client.Get(entries[0].SubDownloadLink)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	data, err = decodeArchive(data)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (o *OpenSubtitles) search(hash uint64, size int64, lang string) ([]osEntry, error) {
+	url := fmt.Sprintf("%s/moviehash-%016x/moviebytesize-%d/sublanguageid-%s", openSubtitlesSearchURL, hash, size, lang)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", o.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entries []osEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,13 @@
+package napigo
+
+// SubtitleProvider is implemented by subtitle sources that can look up and
+// fetch subtitles for a video file. Napi and OpenSubtitles both implement
+// this interface so callers can mix and match providers, e.g. via
+// MultiProvider.
+type SubtitleProvider interface {
+	// Search returns one SearchResult per requested language, indicating
+	// whether subtitles were found.
+	Search(fname string, langs []string) ([]SearchResult, error)
+	// Download returns subtitles for the given video file and language.
+	Download(fname, lang string) (string, error)
+}
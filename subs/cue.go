@@ -0,0 +1,11 @@
+package subs
+
+import "time"
+
+// Cue is a single subtitle entry with a start/end time and text lines,
+// independent of the source format.
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Lines []string
+}
@@ -0,0 +1,48 @@
+// Package subs parses the subtitle text formats Napiprojekt and other
+// providers return (MicroDVD, MPL2, TMP) into a common Cue structure, and
+// writes that structure out as SRT or WebVTT.
+package subs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Format identifies a subtitle text format.
+type Format int
+
+const (
+	// FormatUnknown is returned when the format could not be determined.
+	FormatUnknown Format = iota
+	// FormatMicroDVD is "{start}{end}text", with start/end as frame
+	// numbers relative to a frame rate.
+	FormatMicroDVD
+	// FormatMPL2 is "[start][end]text", with start/end in deciseconds.
+	FormatMPL2
+	// FormatTMP is "HH:MM:SS:text".
+	FormatTMP
+)
+
+var tmpLineRE = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}:`)
+
+// Sniff inspects the first non-empty line of data and returns the detected
+// Format.
+func Sniff(data []byte) Format {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return FormatMicroDVD
+		case strings.HasPrefix(line, "["):
+			return FormatMPL2
+		case tmpLineRE.MatchString(line):
+			return FormatTMP
+		default:
+			return FormatUnknown
+		}
+	}
+	return FormatUnknown
+}
@@ -0,0 +1,136 @@
+package subs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFPS is used to convert MicroDVD frame numbers to durations when the
+// caller doesn't know the video's actual frame rate.
+const DefaultFPS = 23.976
+
+// tmpCueDuration is how long a TMP cue stays on screen; TMP only carries a
+// start time, so subtitles get a fixed display window.
+const tmpCueDuration = 2 * time.Second
+
+// Parse detects data's Format and parses it into a slice of Cue. fps is only
+// used for MicroDVD, which stores frame numbers rather than timestamps; pass
+// 0 to use DefaultFPS.
+func Parse(data []byte, fps float64) ([]Cue, error) {
+	switch Sniff(data) {
+	case FormatMicroDVD:
+		return parseMicroDVD(data, fps)
+	case FormatMPL2:
+		return parseMPL2(data)
+	case FormatTMP:
+		return parseTMP(data)
+	default:
+		return nil, fmt.Errorf("subs: unrecognized subtitle format")
+	}
+}
+
+var microDVDLineRE = regexp.MustCompile(`^\{(\d+)\}\{(\d+)\}(.*)$`)
+
+func parseMicroDVD(data []byte, fps float64) ([]Cue, error) {
+	if fps <= 0 {
+		fps = DefaultFPS
+	}
+	var cues []Cue
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		m := microDVDLineRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		startFrame, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, err
+		}
+		endFrame, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, Cue{
+			Start: frameDuration(startFrame, fps),
+			End:   frameDuration(endFrame, fps),
+			Lines: splitLines(m[3]),
+		})
+	}
+	return cues, sc.Err()
+}
+
+func frameDuration(frame int, fps float64) time.Duration {
+	return time.Duration(float64(frame) / fps * float64(time.Second))
+}
+
+var mpl2LineRE = regexp.MustCompile(`^\[(-?\d+)\]\[(-?\d+)\](.*)$`)
+
+func parseMPL2(data []byte) ([]Cue, error) {
+	var cues []Cue
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		m := mpl2LineRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, err
+		}
+		end, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, Cue{
+			Start: time.Duration(start) * 100 * time.Millisecond,
+			End:   time.Duration(end) * 100 * time.Millisecond,
+			Lines: splitLines(m[3]),
+		})
+	}
+	return cues, sc.Err()
+}
+
+var tmpLineParseRE = regexp.MustCompile(`^(\d{2}):(\d{2}):(\d{2}):(.*)$`)
+
+func parseTMP(data []byte) ([]Cue, error) {
+	var cues []Cue
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		m := tmpLineParseRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		h, _ := strconv.Atoi(m[1])
+		mnt, _ := strconv.Atoi(m[2])
+		s, _ := strconv.Atoi(m[3])
+		start := time.Duration(h)*time.Hour + time.Duration(mnt)*time.Minute + time.Duration(s)*time.Second
+		cues = append(cues, Cue{
+			Start: start,
+			End:   start + tmpCueDuration,
+			Lines: splitLines(m[4]),
+		})
+	}
+	return cues, sc.Err()
+}
+
+// splitLines splits a MicroDVD/MPL2 cue's text on its "|" line separator.
+func splitLines(s string) []string {
+	return strings.Split(s, "|")
+}
@@ -0,0 +1,75 @@
+package subs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMicroDVD(t *testing.T) {
+	data := []byte("{0}{24}Hello|World\n{24}{48}Second line\n")
+	cues, err := Parse(data, 24)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Cue{
+		{Start: 0, End: time.Second, Lines: []string{"Hello", "World"}},
+		{Start: time.Second, End: 2 * time.Second, Lines: []string{"Second line"}},
+	}
+	if !reflect.DeepEqual(cues, want) {
+		t.Errorf("Parse() = %+v, want %+v", cues, want)
+	}
+}
+
+func TestParseMicroDVDDefaultFPS(t *testing.T) {
+	cues, err := Parse([]byte("{0}{24}test\n"), 0)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := frameDuration(24, DefaultFPS)
+	if cues[0].End != want {
+		t.Errorf("End = %v, want %v", cues[0].End, want)
+	}
+}
+
+func TestParseMPL2(t *testing.T) {
+	cues, err := Parse([]byte("[0][50]Hello|World\n"), 0)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Cue{
+		{Start: 0, End: 5 * time.Second, Lines: []string{"Hello", "World"}},
+	}
+	if !reflect.DeepEqual(cues, want) {
+		t.Errorf("Parse() = %+v, want %+v", cues, want)
+	}
+}
+
+func TestParseTMP(t *testing.T) {
+	cues, err := Parse([]byte("00:01:02:Hello there\n"), 0)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	wantStart := time.Minute + 2*time.Second
+	if cues[0].Start != wantStart {
+		t.Errorf("Start = %v, want %v", cues[0].Start, wantStart)
+	}
+	if cues[0].End != wantStart+tmpCueDuration {
+		t.Errorf("End = %v, want %v", cues[0].End, wantStart+tmpCueDuration)
+	}
+	if !reflect.DeepEqual(cues[0].Lines, []string{"Hello there"}) {
+		t.Errorf("Lines = %v, want [Hello there]", cues[0].Lines)
+	}
+}
+
+func TestSniffUnknown(t *testing.T) {
+	if f := Sniff([]byte("plain text\n")); f != FormatUnknown {
+		t.Errorf("Sniff() = %v, want FormatUnknown", f)
+	}
+}
+
+func TestParseUnrecognized(t *testing.T) {
+	if _, err := Parse([]byte("not a subtitle\n"), 0); err == nil {
+		t.Error("Parse() error = nil, want error for unrecognized format")
+	}
+}
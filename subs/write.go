@@ -0,0 +1,52 @@
+package subs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteSRT writes cues to w in SubRip (.srt) format.
+func WriteSRT(w io.Writer, cues []Cue) error {
+	for i, c := range cues {
+		if _, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n", i+1, srtTime(c.Start), srtTime(c.End), strings.Join(c.Lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteVTT writes cues to w in WebVTT format.
+func WriteVTT(w io.Writer, cues []Cue) error {
+	if _, err := fmt.Fprint(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	for _, c := range cues {
+		if _, err := fmt.Fprintf(w, "%s --> %s\n%s\n\n", vttTime(c.Start), vttTime(c.End), strings.Join(c.Lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func srtTime(d time.Duration) string {
+	h, m, s, ms := splitDuration(d)
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, ms)
+}
+
+func vttTime(d time.Duration) string {
+	h, m, s, ms := splitDuration(d)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
+
+func splitDuration(d time.Duration) (h, m, s, ms int) {
+	h = int(d / time.Hour)
+	d -= time.Duration(h) * time.Hour
+	m = int(d / time.Minute)
+	d -= time.Duration(m) * time.Minute
+	s = int(d / time.Second)
+	d -= time.Duration(s) * time.Second
+	ms = int(d / time.Millisecond)
+	return
+}
@@ -0,0 +1,35 @@
+package subs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWriteSRT(t *testing.T) {
+	cues := []Cue{
+		{Start: 1500 * time.Millisecond, End: 3*time.Second + 250*time.Millisecond, Lines: []string{"Hello", "World"}},
+	}
+	var buf bytes.Buffer
+	if err := WriteSRT(&buf, cues); err != nil {
+		t.Fatalf("WriteSRT: %v", err)
+	}
+	want := "1\n00:00:01,500 --> 00:00:03,250\nHello\nWorld\n\n"
+	if buf.String() != want {
+		t.Errorf("WriteSRT() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteVTT(t *testing.T) {
+	cues := []Cue{
+		{Start: time.Second, End: 2 * time.Second, Lines: []string{"Hi"}},
+	}
+	var buf bytes.Buffer
+	if err := WriteVTT(&buf, cues); err != nil {
+		t.Fatalf("WriteVTT: %v", err)
+	}
+	want := "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHi\n\n"
+	if buf.String() != want {
+		t.Errorf("WriteVTT() = %q, want %q", buf.String(), want)
+	}
+}
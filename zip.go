@@ -0,0 +1,35 @@
+package napigo
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/ioutil"
+)
+
+// errEmptyZip is returned when a ZIP archive has no entries to extract.
+var errEmptyZip = errors.New("zip archive is empty")
+
+// unzipSingleFile extracts the first file found in a ZIP archive held in
+// memory, returning its contents and original name so callers can preserve
+// the subtitle's original filename and extension.
+func unzipSingleFile(data []byte) ([]byte, string, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(r.File) == 0 {
+		return nil, "", errEmptyZip
+	}
+	f := r.File[0]
+	rc, err := f.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	defer rc.Close()
+	contents, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, "", err
+	}
+	return contents, f.Name, nil
+}